@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"context"
+	"time"
+
+	"github.com/scanet9/scv-mongo-framework/repository"
+)
+
+//RevokedToken is the document persisted by TokenBlacklist to back server-side token revocation
+type RevokedToken struct {
+	Token     string    `bson:"token"`
+	RevokedAt time.Time `bson:"revokedAt"`
+}
+
+//TokenBlacklist backs token revocation using any repository.Repository[RevokedToken] implementation,
+//such as an infrastructure.MongoRepository[RevokedToken] or the inmemory adapter
+type TokenBlacklist struct {
+	repo repository.Repository[RevokedToken]
+}
+
+//NewTokenBlacklist creates a TokenBlacklist backed by the received repository
+func NewTokenBlacklist(repo repository.Repository[RevokedToken]) *TokenBlacklist {
+	return &TokenBlacklist{repo: repo}
+}
+
+//RevokeToken stores the received raw token in the blacklist, so that IsRevoked reports it as revoked from then on
+func (b *TokenBlacklist) RevokeToken(ctx context.Context, token string) error {
+	_, err := b.repo.Create(ctx, RevokedToken{Token: token, RevokedAt: time.Now()})
+	return err
+}
+
+//IsRevoked reports whether the received raw token has been revoked. It matches the AuthOptions.IsRevoked signature.
+func (b *TokenBlacklist) IsRevoked(ctx context.Context, token string) (bool, error) {
+	tokens, err := b.repo.Get(ctx, map[string]interface{}{"token": token}, nil, nil)
+	if err != nil {
+		return false, err
+	}
+	return len(tokens) > 0, nil
+}
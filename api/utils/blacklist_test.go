@@ -0,0 +1,28 @@
+package utils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/scanet9/scv-mongo-framework/infrastructure/inmemory"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTokenBlacklist_RevokeAndIsRevoked checks that IsRevoked reports true only for a token that was revoked
+// through RevokeToken, backed here by the in-memory repository adapter
+func TestTokenBlacklist_RevokeAndIsRevoked(t *testing.T) {
+	// Arrange
+	blacklist := NewTokenBlacklist(inmemory.NewRepository[RevokedToken]())
+
+	// Act
+	revokeErr := blacklist.RevokeToken(context.Background(), "revoked-token")
+	revoked, revokedErr := blacklist.IsRevoked(context.Background(), "revoked-token")
+	notRevoked, notRevokedErr := blacklist.IsRevoked(context.Background(), "other-token")
+
+	// Assert
+	assert.Equal(t, nil, revokeErr)
+	assert.Equal(t, nil, revokedErr)
+	assert.True(t, revoked)
+	assert.Equal(t, nil, notRevokedErr)
+	assert.False(t, notRevoked)
+}
@@ -0,0 +1,189 @@
+package utils
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type contextKey string
+
+const claimsContextKey contextKey = "claims"
+
+//AuthOptions configures JWTMiddleware
+type AuthOptions struct {
+	// Secret is the HMAC signing secret, used to build the default KeyFunc when KeyFunc and JWKSURL are not set
+	Secret string
+	// KeyFunc resolves the key used to verify a token's signature, as required by jwt.ParseWithClaims.
+	// Set it directly to support RS256/ES256 with a fixed public key. Takes precedence over JWKSURL and Secret.
+	KeyFunc jwt.Keyfunc
+	// JWKSURL, when set and KeyFunc is not, builds a KeyFunc that fetches and caches RSA public keys
+	// from the given JSON Web Key Set endpoint, for verifying RS256 tokens
+	JWKSURL string
+	// JWKSCacheTTL is how long a fetched JWKS is cached for. Defaults to 1 hour.
+	JWKSCacheTTL time.Duration
+	// IsRevoked, when set, is called with the raw token string to check it against a revocation blacklist,
+	// such as the one backed by TokenBlacklist
+	IsRevoked func(ctx context.Context, token string) (bool, error)
+}
+
+func (o AuthOptions) keyFunc() jwt.Keyfunc {
+	if o.KeyFunc != nil {
+		return o.KeyFunc
+	}
+	if o.JWKSURL != "" {
+		return newJWKSKeyFunc(o.JWKSURL, o.JWKSCacheTTL)
+	}
+
+	secret := o.Secret
+	return func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(secret), nil
+	}
+}
+
+//ClaimsFromContext returns the JWT claims stored in the context by JWTMiddleware
+func ClaimsFromContext(ctx context.Context) (jwt.MapClaims, bool) {
+	claims, ok := ctx.Value(claimsContextKey).(jwt.MapClaims)
+	return claims, ok
+}
+
+//RequireScopes returns a middleware that responds with 403 Forbidden unless the request context's claims
+//carry a space separated "scope" claim containing every one of the received scopes
+func RequireScopes(scopes ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, ok := ClaimsFromContext(r.Context())
+			if !ok {
+				ResponseError(w, r, http.StatusForbidden, "no claims found in the request context")
+				return
+			}
+
+			scopeClaim, _ := claims["scope"].(string)
+			grantedScopes := strings.Fields(scopeClaim)
+			for _, required := range scopes {
+				if !containsScope(grantedScopes, required) {
+					ResponseError(w, r, http.StatusForbidden, fmt.Sprintf("missing required scope: %s", required))
+					return
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func containsScope(granted []string, required string) bool {
+	for _, scope := range granted {
+		if scope == required {
+			return true
+		}
+	}
+	return false
+}
+
+//newJWKSKeyFunc builds a jwt.Keyfunc that resolves RS256 public keys from the JWKS served at url,
+//caching the fetched key set for ttl (defaulting to 1 hour)
+func newJWKSKeyFunc(url string, ttl time.Duration) jwt.Keyfunc {
+	if ttl == 0 {
+		ttl = time.Hour
+	}
+	cache := &jwksCache{url: url, ttl: ttl}
+
+	return func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+
+		kid, _ := token.Header["kid"].(string)
+		return cache.key(kid)
+	}
+}
+
+type jwksCache struct {
+	url string
+	ttl time.Duration
+
+	mu        sync.Mutex
+	keys      map[string]*rsa.PublicKey
+	fetchedAt time.Time
+}
+
+func (c *jwksCache) key(kid string) (*rsa.PublicKey, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.keys == nil || time.Since(c.fetchedAt) > c.ttl {
+		keys, err := fetchJWKS(c.url)
+		if err != nil {
+			return nil, err
+		}
+		c.keys = keys
+		c.fetchedAt = time.Now()
+	}
+
+	key, ok := c.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("no matching JWKS key found for kid %q", kid)
+	}
+	return key, nil
+}
+
+type jwk struct {
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+type jwksDocument struct {
+	Keys []jwk `json:"keys"`
+}
+
+func fetchJWKS(url string) (map[string]*rsa.PublicKey, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("an unexpected error happened while fetching the JWKS: %s", err)
+	}
+	defer resp.Body.Close()
+
+	var doc jwksDocument
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("an unexpected error happened while decoding the JWKS: %s", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(doc.Keys))
+	for _, k := range doc.Keys {
+		publicKey, err := parseRSAPublicKey(k.N, k.E)
+		if err != nil {
+			return nil, err
+		}
+		keys[k.Kid] = publicKey
+	}
+	return keys, nil
+}
+
+func parseRSAPublicKey(modulus, exponent string) (*rsa.PublicKey, error) {
+	nBytes, err := base64.RawURLEncoding.DecodeString(modulus)
+	if err != nil {
+		return nil, fmt.Errorf("an unexpected error happened while decoding the JWKS modulus: %s", err)
+	}
+	eBytes, err := base64.RawURLEncoding.DecodeString(exponent)
+	if err != nil {
+		return nil, fmt.Errorf("an unexpected error happened while decoding the JWKS exponent: %s", err)
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(nBytes),
+		E: int(new(big.Int).SetBytes(eBytes).Int64()),
+	}, nil
+}
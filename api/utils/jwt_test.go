@@ -0,0 +1,166 @@
+package utils
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestClaimsFromContext_Ok checks that ClaimsFromContext returns the claims stored by JWTMiddleware
+func TestClaimsFromContext_Ok(t *testing.T) {
+	// Arrange
+	claims := jwt.MapClaims{"sub": "user-1"}
+	ctx := context.WithValue(context.Background(), claimsContextKey, claims)
+
+	// Act
+	result, ok := ClaimsFromContext(ctx)
+
+	// Assert
+	assert.True(t, ok)
+	assert.Equal(t, claims, result)
+}
+
+// TestClaimsFromContext_NotFound checks that ClaimsFromContext reports false when no claims were stored
+func TestClaimsFromContext_NotFound(t *testing.T) {
+	// Act
+	_, ok := ClaimsFromContext(context.Background())
+
+	// Assert
+	assert.False(t, ok)
+}
+
+// TestRequireScopes_Ok checks that RequireScopes calls the wrapped handler when every required scope is granted
+func TestRequireScopes_Ok(t *testing.T) {
+	// Arrange
+	called := false
+	handler := RequireScopes("read", "write")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	claims := jwt.MapClaims{"scope": "read write admin"}
+	ctx := context.WithValue(context.Background(), claimsContextKey, claims)
+	r := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+	w := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(w, r)
+
+	// Assert
+	assert.True(t, called)
+}
+
+// TestRequireScopes_MissingScope checks that RequireScopes responds with 403 Forbidden and does not call the
+// wrapped handler when a required scope is missing
+func TestRequireScopes_MissingScope(t *testing.T) {
+	// Arrange
+	called := false
+	handler := RequireScopes("admin")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	claims := jwt.MapClaims{"scope": "read"}
+	ctx := context.WithValue(context.Background(), claimsContextKey, claims)
+	r := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+	w := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(w, r)
+
+	// Assert
+	assert.False(t, called)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+// TestRequireScopes_NoClaims checks that RequireScopes responds with 403 Forbidden when the request context
+// carries no claims
+func TestRequireScopes_NoClaims(t *testing.T) {
+	// Arrange
+	handler := RequireScopes("read")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(w, r)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func jwkFor(kid string, key *rsa.PublicKey) jwk {
+	return jwk{
+		Kid: kid,
+		N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+// TestNewJWKSKeyFunc_Ok checks that the returned jwt.Keyfunc fetches and resolves the RSA public key matching
+// the token's kid
+func TestNewJWKSKeyFunc_Ok(t *testing.T) {
+	// Arrange
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Equal(t, nil, err)
+	const kid = "test-kid"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(jwksDocument{Keys: []jwk{jwkFor(kid, &privateKey.PublicKey)}})
+	}))
+	defer server.Close()
+
+	keyFunc := newJWKSKeyFunc(server.URL, time.Minute)
+	token := &jwt.Token{Method: jwt.SigningMethodRS256, Header: map[string]interface{}{"kid": kid}}
+
+	// Act
+	key, keyErr := keyFunc(token)
+
+	// Assert
+	assert.Equal(t, nil, keyErr)
+	assert.Equal(t, privateKey.PublicKey, *key.(*rsa.PublicKey))
+}
+
+// TestNewJWKSKeyFunc_RejectsNonRSAMethod checks that the returned jwt.Keyfunc rejects tokens that were not
+// signed with an RSA method, so the JWKS algorithm resolution can't be influenced by the token's own header
+func TestNewJWKSKeyFunc_RejectsNonRSAMethod(t *testing.T) {
+	// Arrange
+	keyFunc := newJWKSKeyFunc("http://unused.invalid", time.Minute)
+	token := &jwt.Token{Method: jwt.SigningMethodHS256, Header: map[string]interface{}{"alg": "HS256"}}
+
+	// Act
+	_, err := keyFunc(token)
+
+	// Assert
+	assert.NotEmpty(t, err)
+}
+
+// TestNewJWKSKeyFunc_CachesKeys checks that the JWKS is fetched once and reused for subsequent calls within ttl
+func TestNewJWKSKeyFunc_CachesKeys(t *testing.T) {
+	// Arrange
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Equal(t, nil, err)
+	const kid = "cached-kid"
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		json.NewEncoder(w).Encode(jwksDocument{Keys: []jwk{jwkFor(kid, &privateKey.PublicKey)}})
+	}))
+	defer server.Close()
+
+	keyFunc := newJWKSKeyFunc(server.URL, time.Minute)
+	token := &jwt.Token{Method: jwt.SigningMethodRS256, Header: map[string]interface{}{"kid": kid}}
+
+	// Act
+	_, err1 := keyFunc(token)
+	_, err2 := keyFunc(token)
+
+	// Assert
+	assert.Equal(t, nil, err1)
+	assert.Equal(t, nil, err2)
+	assert.Equal(t, 1, requests)
+}
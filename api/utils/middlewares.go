@@ -1,43 +1,56 @@
 package utils
 
 import (
-	"fmt"
+	"context"
 	"net/http"
 	"strings"
 
-	"github.com/dgrijalva/jwt-go"
-	"github.com/gorilla/context"
+	"github.com/golang-jwt/jwt/v5"
 )
 
-//JWTMiddleware is a middleware function to check the authorization JWT Bearer token header of the request
-func JWTMiddleware(next http.Handler, secret string) http.Handler {
+//JWTMiddleware is a middleware function to check the authorization JWT Bearer token header of the request,
+//verify its signature according to the received AuthOptions and, if valid, store its claims in the request context
+func JWTMiddleware(next http.Handler, opts AuthOptions) http.Handler {
+	keyFunc := opts.keyFunc()
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		authorizationHeader := r.Header.Get("authorization")
-		if authorizationHeader != "" {
-			bearerToken := strings.Split(authorizationHeader, " ")
-			if len(bearerToken) == 2 {
-				token, err := jwt.Parse(bearerToken[1], func(token *jwt.Token) (interface{}, error) {
-					if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-						return nil, fmt.Errorf("there was an error")
-					}
-					return []byte(secret), nil
-				})
-				if err != nil {
-					ResponseError(w, r, http.StatusUnauthorized, err.Error())
-					return
-				}
-				if token.Valid {
-					context.Set(r, "decoded", token.Claims)
-					next.ServeHTTP(w, r)
-				} else {
-					ResponseError(w, r, http.StatusUnauthorized, "invalid authorization token")
-				}
-			} else {
-				ResponseError(w, r, http.StatusUnauthorized, "authorization header not properly formated, should be Bearer + {token}")
-			}
-		} else {
+		if authorizationHeader == "" {
 			ResponseError(w, r, http.StatusUnauthorized, "an authorization header is required")
+			return
+		}
+
+		bearerToken := strings.Split(authorizationHeader, " ")
+		if len(bearerToken) != 2 {
+			ResponseError(w, r, http.StatusUnauthorized, "authorization header not properly formated, should be Bearer + {token}")
+			return
+		}
+		rawToken := bearerToken[1]
+
+		claims := jwt.MapClaims{}
+		token, err := jwt.ParseWithClaims(rawToken, claims, keyFunc)
+		if err != nil {
+			ResponseError(w, r, http.StatusUnauthorized, err.Error())
+			return
 		}
+		if !token.Valid {
+			ResponseError(w, r, http.StatusUnauthorized, "invalid authorization token")
+			return
+		}
+
+		if opts.IsRevoked != nil {
+			revoked, err := opts.IsRevoked(r.Context(), rawToken)
+			if err != nil {
+				ResponseError(w, r, http.StatusUnauthorized, err.Error())
+				return
+			}
+			if revoked {
+				ResponseError(w, r, http.StatusUnauthorized, "token has been revoked")
+				return
+			}
+		}
+
+		ctx := context.WithValue(r.Context(), claimsContextKey, claims)
+		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
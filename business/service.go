@@ -1,12 +1,62 @@
 package business
 
 import (
-	"github.com/scanet9/scv-mongo-framework/infrastructure"
+	"context"
+	"fmt"
+
+	"github.com/scanet9/scv-mongo-framework/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
 )
 
 //Service struct
-type Service struct {
+type Service[T any] struct {
 	db   *mongo.Database
-	repo infrastructure.MongoRepository
+	repo repository.Repository[T]
+}
+
+//NewService creates a Service backed by the received repository.Repository, such as an
+//infrastructure.MongoRepository[T] or the infrastructure/inmemory adapter for unit testing business code
+//without mtest
+func NewService[T any](db *mongo.Database, repo repository.Repository[T]) *Service[T] {
+	return &Service[T]{db: db, repo: repo}
+}
+
+//Create delegates to the underlying repository.Repository to persist a new entity
+func (s *Service[T]) Create(ctx context.Context, entity T) (primitive.ObjectID, error) {
+	return s.repo.Create(ctx, entity)
+}
+
+//GetByID delegates to the underlying repository.Repository to retrieve the entity matching the received ID
+func (s *Service[T]) GetByID(ctx context.Context, ID string) (*T, error) {
+	return s.repo.GetByID(ctx, ID)
+}
+
+//Update delegates to the underlying repository.Repository to update the entity matching the received ID
+func (s *Service[T]) Update(ctx context.Context, ID string, entity T) error {
+	return s.repo.Update(ctx, ID, entity)
+}
+
+//Delete delegates to the underlying repository.Repository to delete the entity matching the received ID
+func (s *Service[T]) Delete(ctx context.Context, ID string) error {
+	return s.repo.Delete(ctx, ID)
+}
+
+//WithTransaction runs fn inside a MongoDB transaction on the service's client, committing it if fn succeeds
+//and rolling it back otherwise. session.WithTransaction retries the callback on TransientTransactionError
+//and retries the commit on UnknownTransactionCommitResult, as recommended by the MongoDB driver.
+//Repository calls made from fn must use the received ctx so that they run inside the transaction.
+func (s *Service[T]) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	err := s.db.Client().UseSessionWithOptions(ctx, options.Session().SetDefaultReadPreference(readpref.Primary()), func(sessCtx mongo.SessionContext) error {
+		_, err := sessCtx.WithTransaction(sessCtx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+			return nil, fn(sessCtx)
+		})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("an unexpected error happened while running the transaction: %s", err)
+	}
+	return nil
 }
@@ -0,0 +1,82 @@
+package business
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/scanet9/scv-mongo-framework/infrastructure/inmemory"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+type testEntity struct {
+	ID string `bson:"_id,omitempty"`
+}
+
+// TestWithTransaction_Ok checks that WithTransaction does not return an error when fn succeeds
+func TestWithTransaction_Ok(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock).Topologies(mtest.ReplicaSet))
+	defer mt.Close()
+
+	mt.Run("", func(mt *mtest.T) {
+		// Arrange
+		s := Service[testEntity]{db: mt.DB}
+
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		// Act
+		err := s.WithTransaction(context.Background(), func(ctx context.Context) error {
+			return nil
+		})
+
+		// Assert
+		assert.Equal(t, nil, err)
+	})
+}
+
+// TestWithTransaction_FnError checks that WithTransaction returns an error when fn fails, rolling back the transaction
+func TestWithTransaction_FnError(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock).Topologies(mtest.ReplicaSet))
+	defer mt.Close()
+
+	mt.Run("", func(mt *mtest.T) {
+		// Arrange
+		s := Service[testEntity]{db: mt.DB}
+		expectedError := errors.New("fn error")
+
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		// Act
+		err := s.WithTransaction(context.Background(), func(ctx context.Context) error {
+			return expectedError
+		})
+
+		// Assert
+		assert.NotEmpty(t, err)
+	})
+}
+
+// TestService_CRUD_Ok checks that Create, GetByID, Update and Delete delegate to the underlying
+// repository.Repository, backed here by the in-memory adapter instead of mtest
+func TestService_CRUD_Ok(t *testing.T) {
+	// Arrange
+	s := NewService[testEntity](nil, inmemory.NewRepository[testEntity]())
+
+	// Act
+	id, createErr := s.Create(context.Background(), testEntity{})
+	created, getErr := s.GetByID(context.Background(), id.Hex())
+	updateErr := s.Update(context.Background(), id.Hex(), testEntity{ID: "updated"})
+	updated, _ := s.GetByID(context.Background(), id.Hex())
+	deleteErr := s.Delete(context.Background(), id.Hex())
+	_, getAfterDeleteErr := s.GetByID(context.Background(), id.Hex())
+
+	// Assert
+	assert.Equal(t, nil, createErr)
+	assert.Equal(t, nil, getErr)
+	assert.NotNil(t, created)
+	assert.Equal(t, nil, updateErr)
+	assert.Equal(t, "updated", updated.ID)
+	assert.Equal(t, nil, deleteErr)
+	assert.NotEmpty(t, getAfterDeleteErr)
+}
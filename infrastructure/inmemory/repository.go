@@ -0,0 +1,293 @@
+// Package inmemory provides an in-memory adapter for the repository.Repository port, so business code
+// can be unit tested without depending on a real MongoDB instance or mtest.
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/scanet9/scv-mongo-framework/query"
+	"github.com/scanet9/scv-mongo-framework/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+//ErrAggregationNotSupported is returned by Aggregate, as this adapter does not interpret aggregation pipelines
+var ErrAggregationNotSupported = errors.New("aggregation pipelines are not supported by the in-memory repository")
+
+//Repository is an in-memory implementation of the repository.Repository port for a document of type T.
+//Get, UpdateMany, DeleteMany and BulkWrite match every filter key against the entity's BSON field names
+//for equality; operators such as "$gt" are not supported and never match.
+type Repository[T any] struct {
+	mu   sync.RWMutex
+	docs map[primitive.ObjectID]T
+}
+
+//NewRepository creates an empty in-memory Repository
+func NewRepository[T any]() *Repository[T] {
+	return &Repository[T]{docs: make(map[primitive.ObjectID]T)}
+}
+
+// Create stores a new entity and returns its generated ObjectID
+func (r *Repository[T]) Create(ctx context.Context, entity T) (primitive.ObjectID, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := primitive.NewObjectID()
+	r.docs[id] = entity
+	return id, nil
+}
+
+// Get returns the stored entities matching the received filter, skipping and taking the received amount if provided.
+// queryOpts is accepted for interface compliance; this adapter does not support soft-delete filtering
+func (r *Repository[T]) Get(ctx context.Context, filter map[string]interface{}, skip, take *int, queryOpts ...repository.QueryOption) ([]T, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entities, err := r.filtered(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	start := 0
+	if skip != nil {
+		start = *skip
+	}
+	if start > len(entities) {
+		start = len(entities)
+	}
+	end := len(entities)
+	if take != nil && start+*take < end {
+		end = start + *take
+	}
+	return entities[start:end], nil
+}
+
+func (r *Repository[T]) filtered(filter map[string]interface{}) ([]T, error) {
+	entities := make([]T, 0, len(r.docs))
+	for docID, entity := range r.docs {
+		ok, err := matches(docID, entity, filter)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			entities = append(entities, entity)
+		}
+	}
+	return entities, nil
+}
+
+//matches reports whether entity satisfies every key in filter, comparing against docID for "_id" and against
+//the entity's marshalled BSON fields otherwise
+func matches[T any](docID primitive.ObjectID, entity T, filter map[string]interface{}) (bool, error) {
+	if len(filter) == 0 {
+		return true, nil
+	}
+
+	raw, err := bson.Marshal(entity)
+	if err != nil {
+		return false, fmt.Errorf("an unexpected error happened while filtering the entities: %s", err)
+	}
+	var fields bson.M
+	if err := bson.Unmarshal(raw, &fields); err != nil {
+		return false, fmt.Errorf("an unexpected error happened while filtering the entities: %s", err)
+	}
+
+	for key, want := range filter {
+		if key == "_id" {
+			if id, ok := want.(primitive.ObjectID); !ok || id != docID {
+				return false, nil
+			}
+			continue
+		}
+		if got, ok := fields[key]; !ok || !reflect.DeepEqual(got, want) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// GetByID returns the entity matching the received ID. queryOpts is accepted for interface compliance;
+// this adapter does not support soft-delete filtering
+func (r *Repository[T]) GetByID(ctx context.Context, ID string, queryOpts ...repository.QueryOption) (*T, error) {
+	objectID, err := primitive.ObjectIDFromHex(ID)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entity, ok := r.docs[objectID]
+	if !ok {
+		return nil, mongo.ErrNoDocuments
+	}
+	return &entity, nil
+}
+
+// Update replaces the entity matching the received ID
+func (r *Repository[T]) Update(ctx context.Context, ID string, entity T) error {
+	objectID, err := primitive.ObjectIDFromHex(ID)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.docs[objectID]; !ok {
+		return mongo.ErrNoDocuments
+	}
+	r.docs[objectID] = entity
+	return nil
+}
+
+// Delete removes the entity matching the received ID
+func (r *Repository[T]) Delete(ctx context.Context, ID string) error {
+	objectID, err := primitive.ObjectIDFromHex(ID)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.docs[objectID]; !ok {
+		return mongo.ErrNoDocuments
+	}
+	delete(r.docs, objectID)
+	return nil
+}
+
+// Find returns every stored entity; the query.Builder's filter, sort and projection are not evaluated.
+// queryOpts is accepted for interface compliance; this adapter does not support soft-delete filtering
+func (r *Repository[T]) Find(ctx context.Context, q *query.Builder, queryOpts ...repository.QueryOption) ([]T, error) {
+	return r.Get(ctx, nil, nil, nil)
+}
+
+// Count returns the number of stored entities; the query.Builder's filter is not evaluated
+func (r *Repository[T]) Count(ctx context.Context, q *query.Builder) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return int64(len(r.docs)), nil
+}
+
+// Aggregate is not supported by the in-memory adapter and always returns ErrAggregationNotSupported
+func (r *Repository[T]) Aggregate(ctx context.Context, p mongo.Pipeline, result interface{}) error {
+	return ErrAggregationNotSupported
+}
+
+// CreateMany stores multiple entities and returns their generated ObjectIDs
+func (r *Repository[T]) CreateMany(ctx context.Context, entities []T) ([]primitive.ObjectID, error) {
+	ids := make([]primitive.ObjectID, 0, len(entities))
+	for _, entity := range entities {
+		id, _ := r.Create(ctx, entity)
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// UpdateMany replaces every entity matching the received filter with the received update
+func (r *Repository[T]) UpdateMany(ctx context.Context, filter map[string]interface{}, update T) (matchedCount int64, modifiedCount int64, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for docID, entity := range r.docs {
+		ok, err := matches(docID, entity, filter)
+		if err != nil {
+			return 0, 0, err
+		}
+		if !ok {
+			continue
+		}
+		r.docs[docID] = update
+		matchedCount++
+	}
+	return matchedCount, matchedCount, nil
+}
+
+// DeleteMany removes every entity matching the received filter
+func (r *Repository[T]) DeleteMany(ctx context.Context, filter map[string]interface{}) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var deleted int64
+	for docID, entity := range r.docs {
+		ok, err := matches(docID, entity, filter)
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			continue
+		}
+		delete(r.docs, docID)
+		deleted++
+	}
+	return deleted, nil
+}
+
+// BulkWrite applies the received operations sequentially, ignoring Ordered, and reports any per-operation errors.
+// Like mongo.NewDeleteOneModel and mongo.NewUpdateOneModel, a Delete or Update op affects at most one document:
+// the first one found matching its Filter, not every matching document
+func (r *Repository[T]) BulkWrite(ctx context.Context, ops []repository.BulkOp[T], opts repository.BulkOptions) (repository.BulkResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := repository.BulkResult{}
+	errs := map[int]error{}
+
+	for i, op := range ops {
+		switch {
+		case op.Insert != nil:
+			id := primitive.NewObjectID()
+			r.docs[id] = *op.Insert
+			result.InsertedIDs = append(result.InsertedIDs, id)
+		case op.Delete:
+			docID, ok, err := r.firstMatch(op.Filter)
+			if err != nil {
+				return repository.BulkResult{}, err
+			}
+			if ok {
+				delete(r.docs, docID)
+				result.DeletedCount++
+			}
+		case op.Update != nil:
+			docID, ok, err := r.firstMatch(op.Filter)
+			if err != nil {
+				return repository.BulkResult{}, err
+			}
+			if ok {
+				r.docs[docID] = *op.Update
+				result.MatchedCount++
+				result.ModifiedCount++
+			}
+		default:
+			errs[i] = errors.New("empty bulk operation")
+		}
+	}
+
+	if len(errs) > 0 {
+		result.Errors = errs
+	}
+	return result, nil
+}
+
+//firstMatch returns the ID of an arbitrary stored document matching filter. Map iteration order is random,
+//so which document is "first" is unspecified, mirroring NewDeleteOneModel/NewUpdateOneModel's own lack of
+//ordering guarantees when multiple documents match
+func (r *Repository[T]) firstMatch(filter map[string]interface{}) (primitive.ObjectID, bool, error) {
+	for docID, entity := range r.docs {
+		ok, err := matches(docID, entity, filter)
+		if err != nil {
+			return primitive.ObjectID{}, false, err
+		}
+		if ok {
+			return docID, true, nil
+		}
+	}
+	return primitive.ObjectID{}, false, nil
+}
@@ -0,0 +1,146 @@
+package inmemory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/scanet9/scv-mongo-framework/repository"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var _ repository.Repository[testEntity] = (*Repository[testEntity])(nil)
+
+type testEntity struct {
+	Name string `bson:"name"`
+}
+
+// TestGet_FilterByArbitraryField checks that Get matches a non-"_id" filter key against the entity's BSON
+// field, instead of ignoring it and returning every stored entity
+func TestGet_FilterByArbitraryField(t *testing.T) {
+	// Arrange
+	repo := NewRepository[testEntity]()
+	repo.Create(context.Background(), testEntity{Name: "foo"})
+	repo.Create(context.Background(), testEntity{Name: "bar"})
+
+	// Act
+	result, err := repo.Get(context.Background(), map[string]interface{}{"name": "foo"}, nil, nil)
+
+	// Assert
+	assert.Equal(t, nil, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, "foo", result[0].Name)
+}
+
+// TestCreate_And_GetByID_Ok checks that an entity created through Create can be retrieved through GetByID
+func TestCreate_And_GetByID_Ok(t *testing.T) {
+	// Arrange
+	repo := NewRepository[testEntity]()
+	entity := testEntity{Name: "foo"}
+
+	// Act
+	id, err := repo.Create(context.Background(), entity)
+	result, getErr := repo.GetByID(context.Background(), id.Hex())
+
+	// Assert
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, getErr)
+	assert.Equal(t, entity, *result)
+}
+
+// TestGetByID_NotFound checks that GetByID returns mongo.ErrNoDocuments when the ID does not exist
+func TestGetByID_NotFound(t *testing.T) {
+	// Arrange
+	repo := NewRepository[testEntity]()
+
+	// Act
+	_, err := repo.GetByID(context.Background(), "5f43d1c0e6b5f9a1a4c8d9a0")
+
+	// Assert
+	assert.Equal(t, mongo.ErrNoDocuments, err)
+}
+
+// TestUpdate_Ok checks that Update replaces the stored entity matching the received ID
+func TestUpdate_Ok(t *testing.T) {
+	// Arrange
+	repo := NewRepository[testEntity]()
+	id, _ := repo.Create(context.Background(), testEntity{Name: "foo"})
+
+	// Act
+	err := repo.Update(context.Background(), id.Hex(), testEntity{Name: "bar"})
+	result, _ := repo.GetByID(context.Background(), id.Hex())
+
+	// Assert
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "bar", result.Name)
+}
+
+// TestDelete_Ok checks that Delete removes the stored entity matching the received ID
+func TestDelete_Ok(t *testing.T) {
+	// Arrange
+	repo := NewRepository[testEntity]()
+	id, _ := repo.Create(context.Background(), testEntity{Name: "foo"})
+
+	// Act
+	err := repo.Delete(context.Background(), id.Hex())
+	_, getErr := repo.GetByID(context.Background(), id.Hex())
+
+	// Assert
+	assert.Equal(t, nil, err)
+	assert.Equal(t, mongo.ErrNoDocuments, getErr)
+}
+
+// TestCount_Ok checks that Count returns the number of stored entities
+func TestCount_Ok(t *testing.T) {
+	// Arrange
+	repo := NewRepository[testEntity]()
+	repo.Create(context.Background(), testEntity{Name: "foo"})
+	repo.Create(context.Background(), testEntity{Name: "bar"})
+
+	// Act
+	count, err := repo.Count(context.Background(), nil)
+
+	// Assert
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(2), count)
+}
+
+// TestAggregate_NotSupported checks that Aggregate always returns ErrAggregationNotSupported
+func TestAggregate_NotSupported(t *testing.T) {
+	// Arrange
+	repo := NewRepository[testEntity]()
+
+	// Act
+	err := repo.Aggregate(context.Background(), mongo.Pipeline{}, &[]testEntity{})
+
+	// Assert
+	assert.Equal(t, ErrAggregationNotSupported, err)
+}
+
+// TestBulkWrite_DeleteAndUpdate_AffectOnlyFirstMatch checks that a Delete or Update op affects a single
+// document, mirroring NewDeleteOneModel/NewUpdateOneModel, even when its filter matches several stored documents
+func TestBulkWrite_DeleteAndUpdate_AffectOnlyFirstMatch(t *testing.T) {
+	// Arrange
+	repo := NewRepository[testEntity]()
+	repo.Create(context.Background(), testEntity{Name: "foo"})
+	repo.Create(context.Background(), testEntity{Name: "foo"})
+	repo.Create(context.Background(), testEntity{Name: "foo"})
+	filter := map[string]interface{}{"name": "foo"}
+	update := testEntity{Name: "bar"}
+	ops := []repository.BulkOp[testEntity]{
+		{Update: &update, Filter: filter},
+		{Delete: true, Filter: filter},
+	}
+
+	// Act
+	result, err := repo.BulkWrite(context.Background(), ops, repository.BulkOptions{})
+	count, countErr := repo.Count(context.Background(), nil)
+
+	// Assert
+	assert.Equal(t, nil, err)
+	assert.EqualValues(t, 1, result.MatchedCount)
+	assert.EqualValues(t, 1, result.ModifiedCount)
+	assert.EqualValues(t, 1, result.DeletedCount)
+	assert.Equal(t, nil, countErr)
+	assert.EqualValues(t, 2, count)
+}
@@ -0,0 +1,32 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ConnectMongoDB opens a connection against a MongoDB instance and returns the requested database
+func ConnectMongoDB(ctx context.Context, name, connectionString string) (*mongo.Database, error) {
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(connectionString))
+	if err != nil {
+		return nil, fmt.Errorf("an unexpected error happened while opening the connection: %s", err)
+	}
+	return pingMongo(client, name, ctx)
+}
+
+// pingMongo checks that the connection against the received client is alive and returns the requested database
+func pingMongo(client *mongo.Client, name string, ctx context.Context) (db *mongo.Database, err error) {
+	defer func() {
+		if recover() != nil {
+			err = fmt.Errorf("an unexpected error happened while opening the connection: %s", err)
+		}
+	}()
+
+	if pingErr := client.Ping(ctx, nil); pingErr != nil {
+		return nil, fmt.Errorf("an unexpected error happened while opening the connection: %s", pingErr)
+	}
+	return client.Database(name), nil
+}
@@ -0,0 +1,397 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/scanet9/scv-mongo-framework/query"
+	"github.com/scanet9/scv-mongo-framework/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoRepository is a MongoDB implementation of the repository.Repository port for a document of type T
+type MongoRepository[T any] struct {
+	DB         *mongo.Database
+	Collection *mongo.Collection
+	opts       RepositoryOptions
+}
+
+//RepositoryOptions configures the opt-in cross-cutting behaviors of a MongoRepository
+type RepositoryOptions struct {
+	Timestamps            bool
+	OptimisticConcurrency bool
+	SoftDelete            bool
+}
+
+//RepositoryOption configures a RepositoryOptions
+type RepositoryOption func(*RepositoryOptions)
+
+//WithTimestamps makes Create and Update set CreatedAt/UpdatedAt on entities embedding repository.Timestamps
+func WithTimestamps() RepositoryOption {
+	return func(o *RepositoryOptions) { o.Timestamps = true }
+}
+
+//WithOptimisticConcurrency makes Update check and increment the version of entities embedding
+//repository.VersionedDocument, returning repository.ErrConflict when the stored version has moved on
+func WithOptimisticConcurrency() RepositoryOption {
+	return func(o *RepositoryOptions) { o.OptimisticConcurrency = true }
+}
+
+//WithSoftDelete makes Delete set DeletedAt instead of removing the document, and makes Get, GetByID and Find
+//exclude soft-deleted documents unless called with repository.WithDeleted(true), on entities embedding
+//repository.SoftDeletable
+func WithSoftDelete() RepositoryOption {
+	return func(o *RepositoryOptions) { o.SoftDelete = true }
+}
+
+//timestamped is implemented by entities embedding repository.Timestamps
+type timestamped interface {
+	SetCreatedAt(time.Time)
+	SetUpdatedAt(time.Time)
+}
+
+//versioned is implemented by entities embedding repository.VersionedDocument
+type versioned interface {
+	GetVersion() int64
+	SetVersion(int64)
+}
+
+//softDeletable is implemented by entities embedding repository.SoftDeletable
+type softDeletable interface {
+	GetDeletedAt() *time.Time
+	SetDeletedAt(*time.Time)
+}
+
+// NewRepository creates a MongoRepository for the given collection, creating the received indexes, if any,
+// plus the indexes required by the received RepositoryOptions
+func NewRepository[T any](db *mongo.Database, collectionName string, indexes []mongo.IndexModel, opts ...RepositoryOption) (*MongoRepository[T], error) {
+	var options RepositoryOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if options.Timestamps {
+		indexes = append(indexes, mongo.IndexModel{Keys: bson.D{{Key: "updatedAt", Value: 1}}})
+	}
+	if options.SoftDelete {
+		indexes = append(indexes, mongo.IndexModel{Keys: bson.D{{Key: "deletedAt", Value: 1}}})
+	}
+
+	collection := db.Collection(collectionName)
+	if len(indexes) > 0 {
+		if _, err := collection.Indexes().CreateMany(context.Background(), indexes); err != nil {
+			return nil, fmt.Errorf("an unexpected error happened while creating the indexes: %s", err)
+		}
+	}
+	return &MongoRepository[T]{DB: db, Collection: collection, opts: options}, nil
+}
+
+//withSoftDeleteFilter merges a {"deletedAt": nil} condition into the received filter when soft-delete is
+//enabled and the caller did not ask to include deleted documents
+func (r *MongoRepository[T]) withSoftDeleteFilter(filter map[string]interface{}, queryOpts ...repository.QueryOption) map[string]interface{} {
+	if !r.opts.SoftDelete || repository.ApplyQueryOptions(queryOpts...).IncludeDeleted {
+		return filter
+	}
+
+	merged := make(map[string]interface{}, len(filter)+1)
+	for k, v := range filter {
+		merged[k] = v
+	}
+	merged["deletedAt"] = nil
+	return merged
+}
+
+// Create inserts a new entity into the collection and returns its generated ObjectID
+func (r *MongoRepository[T]) Create(ctx context.Context, entity T) (primitive.ObjectID, error) {
+	if r.opts.Timestamps {
+		if ts, ok := any(&entity).(timestamped); ok {
+			now := time.Now()
+			ts.SetCreatedAt(now)
+			ts.SetUpdatedAt(now)
+		}
+	}
+	if r.opts.OptimisticConcurrency {
+		if v, ok := any(&entity).(versioned); ok {
+			v.SetVersion(1)
+		}
+	}
+
+	result, err := r.Collection.InsertOne(ctx, entity)
+	if err != nil {
+		return primitive.NilObjectID, fmt.Errorf("an unexpected error happened while creating the entity: %s", err)
+	}
+
+	id, ok := result.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return primitive.NilObjectID, fmt.Errorf("an unexpected error happened while creating the entity: the generated ID is not a valid ObjectID")
+	}
+	return id, nil
+}
+
+// Get returns the entities matching the received filter, skipping and taking the received amount of documents if provided
+func (r *MongoRepository[T]) Get(ctx context.Context, filter map[string]interface{}, skip, take *int, queryOpts ...repository.QueryOption) ([]T, error) {
+	opts := options.Find()
+	if skip != nil {
+		opts.SetSkip(int64(*skip))
+	}
+	if take != nil {
+		opts.SetLimit(int64(*take))
+	}
+
+	cursor, err := r.Collection.Find(ctx, r.withSoftDeleteFilter(filter, queryOpts...), opts)
+	if err != nil {
+		return nil, fmt.Errorf("an unexpected error happened while getting the entities: %s", err)
+	}
+	defer cursor.Close(ctx)
+
+	var result []T
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, fmt.Errorf("an unexpected error happened while decoding the entities: %s", err)
+	}
+	return result, nil
+}
+
+// GetByID returns the entity matching the received ID
+func (r *MongoRepository[T]) GetByID(ctx context.Context, ID string, queryOpts ...repository.QueryOption) (*T, error) {
+	objectID, err := primitive.ObjectIDFromHex(ID)
+	if err != nil {
+		return nil, fmt.Errorf("an unexpected error happened while parsing the ID: %s", err)
+	}
+
+	filter := r.withSoftDeleteFilter(map[string]interface{}{"_id": objectID}, queryOpts...)
+
+	var result T
+	if err := r.Collection.FindOne(ctx, filter).Decode(&result); err != nil {
+		return nil, fmt.Errorf("an unexpected error happened while getting the entity: %s", err)
+	}
+	return &result, nil
+}
+
+// Update updates the entity matching the received ID with the received entity. When the repository was
+// constructed with WithOptimisticConcurrency, it checks the entity's version against the stored one and
+// returns repository.ErrConflict if it has moved on, incrementing it on success
+func (r *MongoRepository[T]) Update(ctx context.Context, ID string, entity T) error {
+	objectID, err := primitive.ObjectIDFromHex(ID)
+	if err != nil {
+		return fmt.Errorf("an unexpected error happened while parsing the ID: %s", err)
+	}
+
+	if r.opts.Timestamps {
+		if ts, ok := any(&entity).(timestamped); ok {
+			ts.SetUpdatedAt(time.Now())
+		}
+	}
+
+	filter := bson.M{"_id": objectID}
+	if r.opts.OptimisticConcurrency {
+		v, ok := any(&entity).(versioned)
+		if !ok {
+			return fmt.Errorf("an unexpected error happened while updating the entity: optimistic concurrency is enabled but the entity does not embed repository.VersionedDocument")
+		}
+		filter["_version"] = v.GetVersion()
+		v.SetVersion(v.GetVersion() + 1)
+	}
+
+	result, err := r.Collection.UpdateOne(ctx, filter, bson.M{"$set": entity})
+	if err != nil {
+		return fmt.Errorf("an unexpected error happened while updating the entity: %s", err)
+	}
+	if result.ModifiedCount == 0 {
+		if r.opts.OptimisticConcurrency {
+			return repository.ErrConflict
+		}
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// Find returns the entities matching the received query.Builder, with its sort, projection, skip and limit applied
+func (r *MongoRepository[T]) Find(ctx context.Context, q *query.Builder, queryOpts ...repository.QueryOption) ([]T, error) {
+	filter := q.Filter()
+	if r.opts.SoftDelete && !repository.ApplyQueryOptions(queryOpts...).IncludeDeleted {
+		filter = append(filter, bson.E{Key: "deletedAt", Value: nil})
+	}
+
+	cursor, err := r.Collection.Find(ctx, filter, q.Options())
+	if err != nil {
+		return nil, fmt.Errorf("an unexpected error happened while getting the entities: %s", err)
+	}
+	defer cursor.Close(ctx)
+
+	var result []T
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, fmt.Errorf("an unexpected error happened while decoding the entities: %s", err)
+	}
+	return result, nil
+}
+
+// Count returns the number of entities matching the received query.Builder
+func (r *MongoRepository[T]) Count(ctx context.Context, q *query.Builder) (int64, error) {
+	count, err := r.Collection.CountDocuments(ctx, q.Filter())
+	if err != nil {
+		return 0, fmt.Errorf("an unexpected error happened while counting the entities: %s", err)
+	}
+	return count, nil
+}
+
+// Aggregate runs the received aggregation pipeline and decodes the result into the caller-supplied slice pointer
+func (r *MongoRepository[T]) Aggregate(ctx context.Context, p mongo.Pipeline, result interface{}) error {
+	cursor, err := r.Collection.Aggregate(ctx, p)
+	if err != nil {
+		return fmt.Errorf("an unexpected error happened while running the aggregation: %s", err)
+	}
+	defer cursor.Close(ctx)
+
+	if err := cursor.All(ctx, result); err != nil {
+		return fmt.Errorf("an unexpected error happened while decoding the aggregation result: %s", err)
+	}
+	return nil
+}
+
+// Delete deletes the entity matching the received ID. When the repository was constructed with
+// WithSoftDelete, it sets DeletedAt instead of removing the document
+func (r *MongoRepository[T]) Delete(ctx context.Context, ID string) error {
+	objectID, err := primitive.ObjectIDFromHex(ID)
+	if err != nil {
+		return fmt.Errorf("an unexpected error happened while parsing the ID: %s", err)
+	}
+
+	if r.opts.SoftDelete {
+		result, err := r.Collection.UpdateOne(ctx, bson.M{"_id": objectID, "deletedAt": nil}, bson.M{"$set": bson.M{"deletedAt": time.Now()}})
+		if err != nil {
+			return fmt.Errorf("an unexpected error happened while deleting the entity: %s", err)
+		}
+		if result.ModifiedCount == 0 {
+			return mongo.ErrNoDocuments
+		}
+		return nil
+	}
+
+	result, err := r.Collection.DeleteOne(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return fmt.Errorf("an unexpected error happened while deleting the entity: %s", err)
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// CreateMany inserts multiple entities in a single InsertMany call and returns their generated ObjectIDs
+func (r *MongoRepository[T]) CreateMany(ctx context.Context, entities []T) ([]primitive.ObjectID, error) {
+	docs := make([]interface{}, len(entities))
+	for i, entity := range entities {
+		docs[i] = entity
+	}
+
+	result, err := r.Collection.InsertMany(ctx, docs)
+	if err != nil {
+		return nil, fmt.Errorf("an unexpected error happened while creating the entities: %s", err)
+	}
+
+	ids := make([]primitive.ObjectID, len(result.InsertedIDs))
+	for i, rawID := range result.InsertedIDs {
+		id, ok := rawID.(primitive.ObjectID)
+		if !ok {
+			return nil, fmt.Errorf("an unexpected error happened while creating the entities: a generated ID is not a valid ObjectID")
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+// UpdateMany updates every entity matching the received filter and returns the matched and modified document counts
+func (r *MongoRepository[T]) UpdateMany(ctx context.Context, filter map[string]interface{}, update T) (matchedCount int64, modifiedCount int64, err error) {
+	result, err := r.Collection.UpdateMany(ctx, filter, bson.M{"$set": update})
+	if err != nil {
+		return 0, 0, fmt.Errorf("an unexpected error happened while updating the entities: %s", err)
+	}
+	return result.MatchedCount, result.ModifiedCount, nil
+}
+
+// DeleteMany deletes every entity matching the received filter and returns the deleted document count
+func (r *MongoRepository[T]) DeleteMany(ctx context.Context, filter map[string]interface{}) (int64, error) {
+	result, err := r.Collection.DeleteMany(ctx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("an unexpected error happened while deleting the entities: %s", err)
+	}
+	return result.DeletedCount, nil
+}
+
+// BulkWrite runs the received operations in a single driver BulkWrite call, returning the per-operation error indexes
+// so that callers can retry the failing subset. mongo.BulkWriteResult does not report client-generated insert
+// IDs, so, like InsertMany, the ObjectID of each insert is generated here before the call; since opts.Ordered
+// may be false, each generated ID is kept indexed by its op's position instead of assuming a submission-order
+// prefix of the models succeeded, and is only reported back once its index is confirmed absent from the errors
+func (r *MongoRepository[T]) BulkWrite(ctx context.Context, ops []repository.BulkOp[T], opts repository.BulkOptions) (repository.BulkResult, error) {
+	models := make([]mongo.WriteModel, 0, len(ops))
+	insertedIDsByIndex := make(map[int]primitive.ObjectID, len(ops))
+	for i, op := range ops {
+		switch {
+		case op.Insert != nil:
+			doc, err := bson.Marshal(*op.Insert)
+			if err != nil {
+				return repository.BulkResult{}, fmt.Errorf("an unexpected error happened while preparing the bulk write: %s", err)
+			}
+			var docElements bson.D
+			if err := bson.Unmarshal(doc, &docElements); err != nil {
+				return repository.BulkResult{}, fmt.Errorf("an unexpected error happened while preparing the bulk write: %s", err)
+			}
+
+			id := primitive.NewObjectID()
+			if existing, ok := docElements.Map()["_id"]; ok {
+				if existingID, ok := existing.(primitive.ObjectID); ok {
+					id = existingID
+				}
+			} else {
+				docElements = append(docElements, bson.E{Key: "_id", Value: id})
+			}
+
+			models = append(models, mongo.NewInsertOneModel().SetDocument(docElements))
+			insertedIDsByIndex[i] = id
+		case op.Delete:
+			models = append(models, mongo.NewDeleteOneModel().SetFilter(op.Filter))
+		case op.Update != nil:
+			models = append(models, mongo.NewUpdateOneModel().SetFilter(op.Filter).SetUpdate(bson.M{"$set": *op.Update}).SetUpsert(op.Upsert))
+		}
+	}
+
+	writeResult, writeErr := r.Collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(opts.Ordered))
+
+	result := repository.BulkResult{}
+	if writeResult != nil {
+		result.MatchedCount = writeResult.MatchedCount
+		result.ModifiedCount = writeResult.ModifiedCount
+		result.DeletedCount = writeResult.DeletedCount
+		result.UpsertedCount = writeResult.UpsertedCount
+	}
+
+	if writeErr != nil {
+		var bulkErr mongo.BulkWriteException
+		if !errors.As(writeErr, &bulkErr) {
+			return result, fmt.Errorf("an unexpected error happened while running the bulk write: %s", writeErr)
+		}
+		result.Errors = make(map[int]error, len(bulkErr.WriteErrors))
+		for _, writeError := range bulkErr.WriteErrors {
+			result.Errors[writeError.Index] = writeError.WriteError
+		}
+	}
+
+	result.InsertedIDs = make([]primitive.ObjectID, 0, len(insertedIDsByIndex))
+	for i := 0; i < len(ops); i++ {
+		id, ok := insertedIDsByIndex[i]
+		if !ok {
+			continue
+		}
+		if _, failed := result.Errors[i]; failed {
+			continue
+		}
+		result.InsertedIDs = append(result.InsertedIDs, id)
+	}
+	return result, nil
+}
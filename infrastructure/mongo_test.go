@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/scanet9/scv-mongo-framework/pipeline"
+	"github.com/scanet9/scv-mongo-framework/query"
+	"github.com/scanet9/scv-mongo-framework/repository"
 	"github.com/sergicanet9/scv-go-tools/v3/mocks"
 	"github.com/stretchr/testify/assert"
 	"go.mongodb.org/mongo-driver/bson"
@@ -16,7 +19,21 @@ import (
 const testEntityName = "test"
 
 type testEntity struct {
-	ID string `bson:"_id,omitempty"`
+	ID primitive.ObjectID `bson:"_id,omitempty"`
+}
+
+// badEntity is used to force a decode error, its Ch field cannot be unmarshalled from BSON
+type badEntity struct {
+	ID primitive.ObjectID `bson:"_id,omitempty"`
+	Ch chan int           `bson:"ch"`
+}
+
+// mixinEntity embeds every opt-in mixin, to exercise the Timestamps, OptimisticConcurrency and SoftDelete behaviors
+type mixinEntity struct {
+	ID primitive.ObjectID `bson:"_id,omitempty"`
+	repository.Timestamps
+	repository.VersionedDocument
+	repository.SoftDeletable
 }
 
 // TestConnectMongoDB_InvalidConnection checks that ConnectMongoDB returns an error when an invalid connection string is provided
@@ -57,6 +74,41 @@ func TestPingMongo_NilDB(t *testing.T) {
 	assert.Equal(t, expectedError, err.Error())
 }
 
+// TestNewRepository_Ok checks that NewRepository does not return an error when no indexes are received
+func TestNewRepository_Ok(t *testing.T) {
+	mt := mocks.NewMongoDB(t)
+	defer mt.Close()
+
+	mt.Run("", func(mt *mtest.T) {
+		// Act
+		repo, err := NewRepository[testEntity](mt.DB, testEntityName, nil)
+
+		// Assert
+		assert.Equal(t, nil, err)
+		assert.NotNil(t, repo)
+	})
+}
+
+// TestNewRepository_CreateIndexesError checks that NewRepository returns an error when the indexes creation fails
+func TestNewRepository_CreateIndexesError(t *testing.T) {
+	mt := mocks.NewMongoDB(t)
+	defer mt.Close()
+
+	mt.Run("", func(mt *mtest.T) {
+		// Arrange
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 0}})
+		indexes := []mongo.IndexModel{
+			{Keys: bson.D{{Key: "field", Value: 1}}},
+		}
+
+		// Act
+		_, err := NewRepository[testEntity](mt.DB, testEntityName, indexes)
+
+		// Assert
+		assert.NotEmpty(t, err)
+	})
+}
+
 // TestCreate_OK checks that Create does not return an error when a valid entity is received
 func TestCreate_OK(t *testing.T) {
 	mt := mocks.NewMongoDB(t)
@@ -64,10 +116,9 @@ func TestCreate_OK(t *testing.T) {
 
 	mt.Run("", func(mt *mtest.T) {
 		// Arrange
-		repo := MongoRepository{
+		repo := MongoRepository[testEntity]{
 			DB:         mt.DB,
 			Collection: mt.DB.Collection(testEntityName),
-			Target:     testEntity{},
 		}
 
 		mt.AddMockResponses(mtest.CreateSuccessResponse())
@@ -77,7 +128,7 @@ func TestCreate_OK(t *testing.T) {
 		id, err := repo.Create(context.Background(), newEntity)
 
 		// Assert
-		assert.IsType(t, newEntity.ID, id)
+		assert.IsType(t, primitive.ObjectID{}, id)
 		assert.Equal(t, nil, err)
 	})
 }
@@ -89,10 +140,9 @@ func TestCreate_InsertOneError(t *testing.T) {
 
 	mt.Run("", func(mt *mtest.T) {
 		// Arrange
-		repo := MongoRepository{
+		repo := MongoRepository[testEntity]{
 			DB:         mt.DB,
 			Collection: mt.DB.Collection(testEntityName),
-			Target:     testEntity{},
 		}
 
 		mt.AddMockResponses(bson.D{{Key: "ok", Value: 0}})
@@ -113,10 +163,9 @@ func TestGet_Ok(t *testing.T) {
 
 	mt.Run("", func(mt *mtest.T) {
 		// Arrange
-		repo := MongoRepository{
+		repo := MongoRepository[testEntity]{
 			DB:         mt.DB,
 			Collection: mt.DB.Collection(testEntityName),
-			Target:     testEntity{},
 		}
 
 		skip := 1
@@ -136,9 +185,7 @@ func TestGet_Ok(t *testing.T) {
 		// Assert
 		assert.Equal(t, nil, err)
 		assert.True(t, len(result) == 1)
-
-		entity := *(result[0].(*testEntity))
-		assert.IsType(t, testEntity{}, entity)
+		assert.IsType(t, testEntity{}, result[0])
 	})
 }
 
@@ -149,10 +196,9 @@ func TestGet_FindError(t *testing.T) {
 
 	mt.Run("", func(mt *mtest.T) {
 		// Arrange
-		repo := MongoRepository{
+		repo := MongoRepository[testEntity]{
 			DB:         mt.DB,
 			Collection: mt.DB.Collection(testEntityName),
-			Target:     testEntity{},
 		}
 
 		mt.AddMockResponses(bson.D{{Key: "ok", Value: 0}})
@@ -172,17 +218,17 @@ func TestGet_DecodeEntryError(t *testing.T) {
 
 	mt.Run("", func(mt *mtest.T) {
 		// Arrange
-		repo := MongoRepository{
+		repo := MongoRepository[badEntity]{
 			DB:         mt.DB,
 			Collection: mt.DB.Collection(testEntityName),
-			Target:     make(chan int),
 		}
 
 		get := mtest.CreateCursorResponse(1,
 			fmt.Sprintf("test.%s", testEntityName),
 			mtest.FirstBatch,
 			bson.D{
-				{Key: "_id", Value: primitive.NewObjectID()}})
+				{Key: "_id", Value: primitive.NewObjectID()},
+				{Key: "ch", Value: "invalid"}})
 		killCursors := mtest.CreateCursorResponse(0, fmt.Sprintf("test.%s", testEntityName), mtest.NextBatch)
 
 		mt.AddMockResponses(get, killCursors)
@@ -195,6 +241,162 @@ func TestGet_DecodeEntryError(t *testing.T) {
 	})
 }
 
+// TestFind_Ok checks that Find does not return an error when a valid query.Builder is received
+func TestFind_Ok(t *testing.T) {
+	mt := mocks.NewMongoDB(t)
+	defer mt.Close()
+
+	mt.Run("", func(mt *mtest.T) {
+		// Arrange
+		repo := MongoRepository[testEntity]{
+			DB:         mt.DB,
+			Collection: mt.DB.Collection(testEntityName),
+		}
+
+		get := mtest.CreateCursorResponse(1,
+			fmt.Sprintf("test.%s", testEntityName),
+			mtest.FirstBatch,
+			bson.D{
+				{Key: "_id", Value: primitive.NewObjectID()}})
+		killCursors := mtest.CreateCursorResponse(0, fmt.Sprintf("test.%s", testEntityName), mtest.NextBatch)
+
+		mt.AddMockResponses(get, killCursors)
+
+		// Act
+		result, err := repo.Find(context.Background(), query.New().SortBy("_id", true))
+
+		// Assert
+		assert.Equal(t, nil, err)
+		assert.True(t, len(result) == 1)
+	})
+}
+
+// TestFind_FindError checks that Find returns an error when Find fails
+func TestFind_FindError(t *testing.T) {
+	mt := mocks.NewMongoDB(t)
+	defer mt.Close()
+
+	mt.Run("", func(mt *mtest.T) {
+		// Arrange
+		repo := MongoRepository[testEntity]{
+			DB:         mt.DB,
+			Collection: mt.DB.Collection(testEntityName),
+		}
+
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 0}})
+
+		// Act
+		_, err := repo.Find(context.Background(), query.New())
+
+		// Assert
+		assert.NotEmpty(t, err)
+	})
+}
+
+// TestCount_Ok checks that Count does not return an error when a valid query.Builder is received
+func TestCount_Ok(t *testing.T) {
+	mt := mocks.NewMongoDB(t)
+	defer mt.Close()
+
+	mt.Run("", func(mt *mtest.T) {
+		// Arrange
+		repo := MongoRepository[testEntity]{
+			DB:         mt.DB,
+			Collection: mt.DB.Collection(testEntityName),
+		}
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(1,
+			fmt.Sprintf("test.%s", testEntityName),
+			mtest.FirstBatch,
+			bson.D{{Key: "n", Value: 1}}))
+
+		// Act
+		count, err := repo.Count(context.Background(), query.New().Eq("name", "foo"))
+
+		// Assert
+		assert.Equal(t, nil, err)
+		assert.Equal(t, int64(1), count)
+	})
+}
+
+// TestCount_CountDocumentsError checks that Count returns an error when CountDocuments fails
+func TestCount_CountDocumentsError(t *testing.T) {
+	mt := mocks.NewMongoDB(t)
+	defer mt.Close()
+
+	mt.Run("", func(mt *mtest.T) {
+		// Arrange
+		repo := MongoRepository[testEntity]{
+			DB:         mt.DB,
+			Collection: mt.DB.Collection(testEntityName),
+		}
+
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 0}})
+
+		// Act
+		_, err := repo.Count(context.Background(), query.New())
+
+		// Assert
+		assert.NotEmpty(t, err)
+	})
+}
+
+// TestAggregate_Ok checks that Aggregate does not return an error when a valid pipeline is received
+func TestAggregate_Ok(t *testing.T) {
+	mt := mocks.NewMongoDB(t)
+	defer mt.Close()
+
+	mt.Run("", func(mt *mtest.T) {
+		// Arrange
+		repo := MongoRepository[testEntity]{
+			DB:         mt.DB,
+			Collection: mt.DB.Collection(testEntityName),
+		}
+
+		get := mtest.CreateCursorResponse(1,
+			fmt.Sprintf("test.%s", testEntityName),
+			mtest.FirstBatch,
+			bson.D{
+				{Key: "_id", Value: primitive.NewObjectID()}})
+		killCursors := mtest.CreateCursorResponse(0, fmt.Sprintf("test.%s", testEntityName), mtest.NextBatch)
+
+		mt.AddMockResponses(get, killCursors)
+
+		p := pipeline.New().Match(bson.D{}).Pipeline()
+		var result []testEntity
+
+		// Act
+		err := repo.Aggregate(context.Background(), p, &result)
+
+		// Assert
+		assert.Equal(t, nil, err)
+		assert.True(t, len(result) == 1)
+	})
+}
+
+// TestAggregate_AggregateError checks that Aggregate returns an error when Aggregate fails
+func TestAggregate_AggregateError(t *testing.T) {
+	mt := mocks.NewMongoDB(t)
+	defer mt.Close()
+
+	mt.Run("", func(mt *mtest.T) {
+		// Arrange
+		repo := MongoRepository[testEntity]{
+			DB:         mt.DB,
+			Collection: mt.DB.Collection(testEntityName),
+		}
+
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 0}})
+		var result []testEntity
+
+		// Act
+		err := repo.Aggregate(context.Background(), pipeline.New().Pipeline(), &result)
+
+		// Assert
+		assert.NotEmpty(t, err)
+	})
+}
+
 // TestGetByID_Ok checks that GetByID does not return an error when the received ID has a valid format
 func TestGetByID_Ok(t *testing.T) {
 	mt := mocks.NewMongoDB(t)
@@ -202,10 +404,9 @@ func TestGetByID_Ok(t *testing.T) {
 
 	mt.Run("", func(mt *mtest.T) {
 		// Arrange
-		repo := MongoRepository{
+		repo := MongoRepository[testEntity]{
 			DB:         mt.DB,
 			Collection: mt.DB.Collection(testEntityName),
-			Target:     testEntity{},
 		}
 
 		get := mtest.CreateCursorResponse(1,
@@ -222,9 +423,7 @@ func TestGetByID_Ok(t *testing.T) {
 
 		// Assert
 		assert.Equal(t, nil, err)
-
-		entity := *(result.(*testEntity))
-		assert.IsType(t, testEntity{}, entity)
+		assert.IsType(t, &testEntity{}, result)
 	})
 }
 
@@ -235,10 +434,9 @@ func TestGetByID_InvalidID(t *testing.T) {
 	defer mt.Close()
 	mt.Run("", func(mt *mtest.T) {
 		// Arrange
-		repo := MongoRepository{
+		repo := MongoRepository[testEntity]{
 			DB:         mt.DB,
 			Collection: mt.DB.Collection(testEntityName),
-			Target:     make(chan int),
 		}
 
 		// Act
@@ -256,10 +454,9 @@ func TestGetByID_FindOneError(t *testing.T) {
 
 	mt.Run("", func(mt *mtest.T) {
 		// Arrange
-		repo := MongoRepository{
+		repo := MongoRepository[testEntity]{
 			DB:         mt.DB,
 			Collection: mt.DB.Collection(testEntityName),
-			Target:     testEntity{},
 		}
 
 		mt.AddMockResponses(bson.D{{Key: "ok", Value: 0}})
@@ -279,10 +476,9 @@ func TestUpdate_OK(t *testing.T) {
 
 	mt.Run("", func(mt *mtest.T) {
 		// Arrange
-		repo := MongoRepository{
+		repo := MongoRepository[testEntity]{
 			DB:         mt.DB,
 			Collection: mt.DB.Collection(testEntityName),
-			Target:     testEntity{},
 		}
 
 		mt.AddMockResponses(bson.D{
@@ -306,10 +502,9 @@ func TestUpdate_InvalidID(t *testing.T) {
 	defer mt.Close()
 	mt.Run("", func(mt *mtest.T) {
 		// Arrange
-		repo := MongoRepository{
+		repo := MongoRepository[testEntity]{
 			DB:         mt.DB,
 			Collection: mt.DB.Collection(testEntityName),
-			Target:     make(chan int),
 		}
 		newEntity := testEntity{}
 
@@ -328,10 +523,9 @@ func TestUpdate_UpdateOneError(t *testing.T) {
 
 	mt.Run("", func(mt *mtest.T) {
 		// Arrange
-		repo := MongoRepository{
+		repo := MongoRepository[testEntity]{
 			DB:         mt.DB,
 			Collection: mt.DB.Collection(testEntityName),
-			Target:     testEntity{},
 		}
 
 		mt.AddMockResponses(bson.D{{Key: "ok", Value: 0}})
@@ -352,10 +546,9 @@ func TestUpdate_NotUpdatedError(t *testing.T) {
 
 	mt.Run("", func(mt *mtest.T) {
 		// Arrange
-		repo := MongoRepository{
+		repo := MongoRepository[testEntity]{
 			DB:         mt.DB,
 			Collection: mt.DB.Collection(testEntityName),
-			Target:     testEntity{},
 		}
 
 		mt.AddMockResponses(bson.D{
@@ -379,10 +572,9 @@ func TestDelete_OK(t *testing.T) {
 
 	mt.Run("", func(mt *mtest.T) {
 		// Arrange
-		repo := MongoRepository{
+		repo := MongoRepository[testEntity]{
 			DB:         mt.DB,
 			Collection: mt.DB.Collection(testEntityName),
-			Target:     testEntity{},
 		}
 
 		mt.AddMockResponses(bson.D{
@@ -405,10 +597,9 @@ func TestDelete_InvalidID(t *testing.T) {
 	defer mt.Close()
 	mt.Run("", func(mt *mtest.T) {
 		// Arrange
-		repo := MongoRepository{
+		repo := MongoRepository[testEntity]{
 			DB:         mt.DB,
 			Collection: mt.DB.Collection(testEntityName),
-			Target:     make(chan int),
 		}
 
 		// Act
@@ -426,10 +617,9 @@ func TestDelete_DeleteOneError(t *testing.T) {
 
 	mt.Run("", func(mt *mtest.T) {
 		// Arrange
-		repo := MongoRepository{
+		repo := MongoRepository[testEntity]{
 			DB:         mt.DB,
 			Collection: mt.DB.Collection(testEntityName),
-			Target:     testEntity{},
 		}
 
 		mt.AddMockResponses(bson.D{{Key: "ok", Value: 0}})
@@ -449,10 +639,9 @@ func TestDelete_NotDeletedError(t *testing.T) {
 
 	mt.Run("", func(mt *mtest.T) {
 		// Arrange
-		repo := MongoRepository{
+		repo := MongoRepository[testEntity]{
 			DB:         mt.DB,
 			Collection: mt.DB.Collection(testEntityName),
-			Target:     testEntity{},
 		}
 
 		mt.AddMockResponses(bson.D{
@@ -466,4 +655,494 @@ func TestDelete_NotDeletedError(t *testing.T) {
 		// Assert
 		assert.Equal(t, mongo.ErrNoDocuments, err)
 	})
-}
\ No newline at end of file
+}
+
+// TestCreateMany_Ok checks that CreateMany does not return an error when valid entities are received
+func TestCreateMany_Ok(t *testing.T) {
+	mt := mocks.NewMongoDB(t)
+	defer mt.Close()
+
+	mt.Run("", func(mt *mtest.T) {
+		// Arrange
+		repo := MongoRepository[testEntity]{
+			DB:         mt.DB,
+			Collection: mt.DB.Collection(testEntityName),
+		}
+
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+		entities := []testEntity{{}, {}}
+
+		// Act
+		ids, err := repo.CreateMany(context.Background(), entities)
+
+		// Assert
+		assert.Equal(t, nil, err)
+		assert.True(t, len(ids) == 2)
+	})
+}
+
+// TestCreateMany_InsertManyError checks that CreateMany returns an error when InsertMany fails
+func TestCreateMany_InsertManyError(t *testing.T) {
+	mt := mocks.NewMongoDB(t)
+	defer mt.Close()
+
+	mt.Run("", func(mt *mtest.T) {
+		// Arrange
+		repo := MongoRepository[testEntity]{
+			DB:         mt.DB,
+			Collection: mt.DB.Collection(testEntityName),
+		}
+
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 0}})
+
+		// Act
+		_, err := repo.CreateMany(context.Background(), []testEntity{{}})
+
+		// Assert
+		assert.NotEmpty(t, err)
+	})
+}
+
+// TestUpdateMany_Ok checks that UpdateMany does not return an error when UpdateMany succeeds
+func TestUpdateMany_Ok(t *testing.T) {
+	mt := mocks.NewMongoDB(t)
+	defer mt.Close()
+
+	mt.Run("", func(mt *mtest.T) {
+		// Arrange
+		repo := MongoRepository[testEntity]{
+			DB:         mt.DB,
+			Collection: mt.DB.Collection(testEntityName),
+		}
+
+		mt.AddMockResponses(bson.D{
+			{Key: "ok", Value: 1},
+			{Key: "n", Value: 2},
+			{Key: "nModified", Value: 2},
+		})
+
+		// Act
+		matched, modified, err := repo.UpdateMany(context.Background(), map[string]interface{}{}, testEntity{})
+
+		// Assert
+		assert.Equal(t, nil, err)
+		assert.Equal(t, int64(2), matched)
+		assert.Equal(t, int64(2), modified)
+	})
+}
+
+// TestUpdateMany_UpdateManyError checks that UpdateMany returns an error when UpdateMany fails
+func TestUpdateMany_UpdateManyError(t *testing.T) {
+	mt := mocks.NewMongoDB(t)
+	defer mt.Close()
+
+	mt.Run("", func(mt *mtest.T) {
+		// Arrange
+		repo := MongoRepository[testEntity]{
+			DB:         mt.DB,
+			Collection: mt.DB.Collection(testEntityName),
+		}
+
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 0}})
+
+		// Act
+		_, _, err := repo.UpdateMany(context.Background(), map[string]interface{}{}, testEntity{})
+
+		// Assert
+		assert.NotEmpty(t, err)
+	})
+}
+
+// TestDeleteMany_Ok checks that DeleteMany does not return an error when DeleteMany succeeds
+func TestDeleteMany_Ok(t *testing.T) {
+	mt := mocks.NewMongoDB(t)
+	defer mt.Close()
+
+	mt.Run("", func(mt *mtest.T) {
+		// Arrange
+		repo := MongoRepository[testEntity]{
+			DB:         mt.DB,
+			Collection: mt.DB.Collection(testEntityName),
+		}
+
+		mt.AddMockResponses(bson.D{
+			{Key: "ok", Value: 1},
+			{Key: "n", Value: 2},
+		})
+
+		// Act
+		deleted, err := repo.DeleteMany(context.Background(), map[string]interface{}{})
+
+		// Assert
+		assert.Equal(t, nil, err)
+		assert.Equal(t, int64(2), deleted)
+	})
+}
+
+// TestDeleteMany_DeleteManyError checks that DeleteMany returns an error when DeleteMany fails
+func TestDeleteMany_DeleteManyError(t *testing.T) {
+	mt := mocks.NewMongoDB(t)
+	defer mt.Close()
+
+	mt.Run("", func(mt *mtest.T) {
+		// Arrange
+		repo := MongoRepository[testEntity]{
+			DB:         mt.DB,
+			Collection: mt.DB.Collection(testEntityName),
+		}
+
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 0}})
+
+		// Act
+		_, err := repo.DeleteMany(context.Background(), map[string]interface{}{})
+
+		// Assert
+		assert.NotEmpty(t, err)
+	})
+}
+
+// TestBulkWrite_Ok checks that BulkWrite does not return an error when the operations succeed
+func TestBulkWrite_Ok(t *testing.T) {
+	mt := mocks.NewMongoDB(t)
+	defer mt.Close()
+
+	mt.Run("", func(mt *mtest.T) {
+		// Arrange
+		repo := MongoRepository[testEntity]{
+			DB:         mt.DB,
+			Collection: mt.DB.Collection(testEntityName),
+		}
+
+		mt.AddMockResponses(bson.D{
+			{Key: "ok", Value: 1},
+			{Key: "n", Value: 1},
+			{Key: "nModified", Value: 1},
+			{Key: "upserted", Value: bson.A{}},
+			{Key: "writeErrors", Value: bson.A{}},
+		})
+		entity := testEntity{}
+		ops := []repository.BulkOp[testEntity]{
+			{Insert: &entity},
+		}
+
+		// Act
+		result, err := repo.BulkWrite(context.Background(), ops, repository.BulkOptions{Ordered: true})
+
+		// Assert
+		assert.Equal(t, nil, err)
+		assert.Empty(t, result.Errors)
+	})
+}
+
+// TestBulkWrite_BulkWriteError checks that BulkWrite returns an error when the driver call itself fails
+func TestBulkWrite_BulkWriteError(t *testing.T) {
+	mt := mocks.NewMongoDB(t)
+	defer mt.Close()
+
+	mt.Run("", func(mt *mtest.T) {
+		// Arrange
+		repo := MongoRepository[testEntity]{
+			DB:         mt.DB,
+			Collection: mt.DB.Collection(testEntityName),
+		}
+
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 0}})
+		entity := testEntity{}
+		ops := []repository.BulkOp[testEntity]{
+			{Insert: &entity},
+		}
+
+		// Act
+		_, err := repo.BulkWrite(context.Background(), ops, repository.BulkOptions{})
+
+		// Assert
+		assert.NotEmpty(t, err)
+	})
+}
+
+// TestNewRepository_WithTimestampsAndSoftDelete_Ok checks that NewRepository does not return an error when no
+// indexes are received and WithTimestamps and WithSoftDelete add their updatedAt and deletedAt indexes
+func TestNewRepository_WithTimestampsAndSoftDelete_Ok(t *testing.T) {
+	mt := mocks.NewMongoDB(t)
+	defer mt.Close()
+
+	mt.Run("", func(mt *mtest.T) {
+		// Arrange
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		// Act
+		repo, err := NewRepository[mixinEntity](mt.DB, testEntityName, nil, WithTimestamps(), WithSoftDelete())
+
+		// Assert
+		assert.Equal(t, nil, err)
+		assert.NotNil(t, repo)
+	})
+}
+
+// TestCreate_WithOptimisticConcurrency_SetsInitialVersion checks that Create does not return an error when
+// optimistic concurrency is enabled
+func TestCreate_WithOptimisticConcurrency_SetsInitialVersion(t *testing.T) {
+	mt := mocks.NewMongoDB(t)
+	defer mt.Close()
+
+	mt.Run("", func(mt *mtest.T) {
+		// Arrange
+		repo := MongoRepository[mixinEntity]{
+			DB:         mt.DB,
+			Collection: mt.DB.Collection(testEntityName),
+			opts:       RepositoryOptions{OptimisticConcurrency: true},
+		}
+
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		// Act
+		id, err := repo.Create(context.Background(), mixinEntity{})
+
+		// Assert
+		assert.Equal(t, nil, err)
+		assert.IsType(t, primitive.ObjectID{}, id)
+	})
+}
+
+// TestUpdate_WithOptimisticConcurrency_Ok checks that Update does not return an error when the stored version
+// still matches the received entity's version
+func TestUpdate_WithOptimisticConcurrency_Ok(t *testing.T) {
+	mt := mocks.NewMongoDB(t)
+	defer mt.Close()
+
+	mt.Run("", func(mt *mtest.T) {
+		// Arrange
+		repo := MongoRepository[mixinEntity]{
+			DB:         mt.DB,
+			Collection: mt.DB.Collection(testEntityName),
+			opts:       RepositoryOptions{OptimisticConcurrency: true},
+		}
+
+		mt.AddMockResponses(bson.D{
+			{Key: "ok", Value: 1},
+			{Key: "nModified", Value: 1},
+		})
+
+		// Act
+		err := repo.Update(context.Background(), primitive.NewObjectID().Hex(), mixinEntity{})
+
+		// Assert
+		assert.Equal(t, nil, err)
+	})
+}
+
+// TestUpdate_WithOptimisticConcurrency_Conflict checks that Update returns repository.ErrConflict, instead of
+// mongo.ErrNoDocuments, when no document matches the entity's version
+func TestUpdate_WithOptimisticConcurrency_Conflict(t *testing.T) {
+	mt := mocks.NewMongoDB(t)
+	defer mt.Close()
+
+	mt.Run("", func(mt *mtest.T) {
+		// Arrange
+		repo := MongoRepository[mixinEntity]{
+			DB:         mt.DB,
+			Collection: mt.DB.Collection(testEntityName),
+			opts:       RepositoryOptions{OptimisticConcurrency: true},
+		}
+
+		mt.AddMockResponses(bson.D{
+			{Key: "ok", Value: 1},
+			{Key: "nModified", Value: 0},
+		})
+
+		// Act
+		err := repo.Update(context.Background(), primitive.NewObjectID().Hex(), mixinEntity{})
+
+		// Assert
+		assert.Equal(t, repository.ErrConflict, err)
+	})
+}
+
+// TestUpdate_WithOptimisticConcurrency_NotVersioned checks that Update returns an error when optimistic
+// concurrency is enabled but the entity does not embed repository.VersionedDocument
+func TestUpdate_WithOptimisticConcurrency_NotVersioned(t *testing.T) {
+	mt := mocks.NewMongoDB(t)
+	defer mt.Close()
+
+	mt.Run("", func(mt *mtest.T) {
+		// Arrange
+		repo := MongoRepository[testEntity]{
+			DB:         mt.DB,
+			Collection: mt.DB.Collection(testEntityName),
+			opts:       RepositoryOptions{OptimisticConcurrency: true},
+		}
+
+		// Act
+		err := repo.Update(context.Background(), primitive.NewObjectID().Hex(), testEntity{})
+
+		// Assert
+		assert.NotEmpty(t, err)
+	})
+}
+
+// TestDelete_WithSoftDelete_Ok checks that Delete sets deletedAt instead of removing the document when
+// soft-delete is enabled
+func TestDelete_WithSoftDelete_Ok(t *testing.T) {
+	mt := mocks.NewMongoDB(t)
+	defer mt.Close()
+
+	mt.Run("", func(mt *mtest.T) {
+		// Arrange
+		repo := MongoRepository[mixinEntity]{
+			DB:         mt.DB,
+			Collection: mt.DB.Collection(testEntityName),
+			opts:       RepositoryOptions{SoftDelete: true},
+		}
+
+		mt.AddMockResponses(bson.D{
+			{Key: "ok", Value: 1},
+			{Key: "nModified", Value: 1},
+		})
+
+		// Act
+		err := repo.Delete(context.Background(), primitive.NewObjectID().Hex())
+
+		// Assert
+		assert.Equal(t, nil, err)
+	})
+}
+
+// TestDelete_WithSoftDelete_NotFound checks that Delete returns mongo.ErrNoDocuments when soft-delete is
+// enabled and no matching, not-yet-deleted document is found
+func TestDelete_WithSoftDelete_NotFound(t *testing.T) {
+	mt := mocks.NewMongoDB(t)
+	defer mt.Close()
+
+	mt.Run("", func(mt *mtest.T) {
+		// Arrange
+		repo := MongoRepository[mixinEntity]{
+			DB:         mt.DB,
+			Collection: mt.DB.Collection(testEntityName),
+			opts:       RepositoryOptions{SoftDelete: true},
+		}
+
+		mt.AddMockResponses(bson.D{
+			{Key: "ok", Value: 1},
+			{Key: "nModified", Value: 0},
+		})
+
+		// Act
+		err := repo.Delete(context.Background(), primitive.NewObjectID().Hex())
+
+		// Assert
+		assert.Equal(t, mongo.ErrNoDocuments, err)
+	})
+}
+
+// TestGetByID_WithSoftDelete_WithDeleted checks that GetByID accepts repository.WithDeleted(true) to include
+// soft-deleted documents
+func TestGetByID_WithSoftDelete_WithDeleted(t *testing.T) {
+	mt := mocks.NewMongoDB(t)
+	defer mt.Close()
+
+	mt.Run("", func(mt *mtest.T) {
+		// Arrange
+		repo := MongoRepository[mixinEntity]{
+			DB:         mt.DB,
+			Collection: mt.DB.Collection(testEntityName),
+			opts:       RepositoryOptions{SoftDelete: true},
+		}
+
+		get := mtest.CreateCursorResponse(1,
+			fmt.Sprintf("test.%s", testEntityName),
+			mtest.FirstBatch,
+			bson.D{
+				{Key: "_id", Value: primitive.NewObjectID()}})
+		killCursors := mtest.CreateCursorResponse(0, fmt.Sprintf("test.%s", testEntityName), mtest.NextBatch)
+
+		mt.AddMockResponses(get, killCursors)
+
+		// Act
+		result, err := repo.GetByID(context.Background(), primitive.NewObjectID().Hex(), repository.WithDeleted(true))
+
+		// Assert
+		assert.Equal(t, nil, err)
+		assert.IsType(t, &mixinEntity{}, result)
+	})
+}
+
+// TestFind_WithSoftDelete_Ok checks that Find does not return an error and decodes the returned documents when
+// soft-delete is enabled
+func TestFind_WithSoftDelete_Ok(t *testing.T) {
+	mt := mocks.NewMongoDB(t)
+	defer mt.Close()
+
+	mt.Run("", func(mt *mtest.T) {
+		// Arrange
+		repo := MongoRepository[mixinEntity]{
+			DB:         mt.DB,
+			Collection: mt.DB.Collection(testEntityName),
+			opts:       RepositoryOptions{SoftDelete: true},
+		}
+
+		get := mtest.CreateCursorResponse(1,
+			fmt.Sprintf("test.%s", testEntityName),
+			mtest.FirstBatch,
+			bson.D{
+				{Key: "_id", Value: primitive.NewObjectID()}})
+		killCursors := mtest.CreateCursorResponse(0, fmt.Sprintf("test.%s", testEntityName), mtest.NextBatch)
+
+		mt.AddMockResponses(get, killCursors)
+
+		// Act
+		result, err := repo.Find(context.Background(), query.New())
+
+		// Assert
+		assert.Equal(t, nil, err)
+		assert.True(t, len(result) == 1)
+	})
+}
+
+// TestBulkWrite_PartialFailure_UnorderedReportsCorrectInsertedIDs checks that BulkWrite does not misattribute
+// a client-generated insert ID to a different, failed operation when Ordered is false and the failing op is
+// not the last one in submission order
+func TestBulkWrite_PartialFailure_UnorderedReportsCorrectInsertedIDs(t *testing.T) {
+	mt := mocks.NewMongoDB(t)
+	defer mt.Close()
+
+	mt.Run("", func(mt *mtest.T) {
+		// Arrange
+		repo := MongoRepository[testEntity]{
+			DB:         mt.DB,
+			Collection: mt.DB.Collection(testEntityName),
+		}
+
+		mt.AddMockResponses(bson.D{
+			{Key: "ok", Value: 1},
+			{Key: "n", Value: 2},
+			{Key: "nModified", Value: 0},
+			{Key: "upserted", Value: bson.A{}},
+			{Key: "writeErrors", Value: bson.A{
+				bson.D{
+					{Key: "index", Value: 1},
+					{Key: "code", Value: 11000},
+					{Key: "errmsg", Value: "duplicate key"},
+				},
+			}},
+		})
+
+		entityA := testEntity{ID: primitive.NewObjectID()}
+		entityB := testEntity{ID: primitive.NewObjectID()}
+		entityC := testEntity{ID: primitive.NewObjectID()}
+		ops := []repository.BulkOp[testEntity]{
+			{Insert: &entityA},
+			{Insert: &entityB},
+			{Insert: &entityC},
+		}
+
+		// Act
+		result, err := repo.BulkWrite(context.Background(), ops, repository.BulkOptions{Ordered: false})
+
+		// Assert
+		assert.Equal(t, nil, err)
+		assert.Equal(t, []primitive.ObjectID{entityA.ID, entityC.ID}, result.InsertedIDs)
+		assert.Contains(t, result.Errors, 1)
+		assert.NotContains(t, result.Errors, 0)
+		assert.NotContains(t, result.Errors, 2)
+	})
+}
@@ -0,0 +1,82 @@
+package pipeline
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+//Builder is a fluent builder for MongoDB aggregation pipelines
+type Builder struct {
+	stages mongo.Pipeline
+}
+
+//New creates an empty Builder
+func New() *Builder {
+	return &Builder{}
+}
+
+//Match adds a $match stage with the received filter
+func (b *Builder) Match(filter bson.D) *Builder {
+	b.stages = append(b.stages, bson.D{{Key: "$match", Value: filter}})
+	return b
+}
+
+//Group adds a $group stage grouping by the received id expression with the received accumulator fields
+func (b *Builder) Group(id interface{}, fields bson.D) *Builder {
+	group := bson.D{{Key: "_id", Value: id}}
+	group = append(group, fields...)
+	b.stages = append(b.stages, bson.D{{Key: "$group", Value: group}})
+	return b
+}
+
+//Lookup adds a $lookup stage joining the received foreign collection into the received field
+func (b *Builder) Lookup(from, localField, foreignField, as string) *Builder {
+	b.stages = append(b.stages, bson.D{{Key: "$lookup", Value: bson.D{
+		{Key: "from", Value: from},
+		{Key: "localField", Value: localField},
+		{Key: "foreignField", Value: foreignField},
+		{Key: "as", Value: as},
+	}}})
+	return b
+}
+
+//Unwind adds a $unwind stage deconstructing the received array field
+func (b *Builder) Unwind(field string) *Builder {
+	b.stages = append(b.stages, bson.D{{Key: "$unwind", Value: "$" + field}})
+	return b
+}
+
+//Project adds a $project stage with the received fields
+func (b *Builder) Project(fields bson.D) *Builder {
+	b.stages = append(b.stages, bson.D{{Key: "$project", Value: fields}})
+	return b
+}
+
+//Sort adds a $sort stage with the received fields
+func (b *Builder) Sort(fields bson.D) *Builder {
+	b.stages = append(b.stages, bson.D{{Key: "$sort", Value: fields}})
+	return b
+}
+
+//Skip adds a $skip stage
+func (b *Builder) Skip(skip int64) *Builder {
+	b.stages = append(b.stages, bson.D{{Key: "$skip", Value: skip}})
+	return b
+}
+
+//Limit adds a $limit stage
+func (b *Builder) Limit(limit int64) *Builder {
+	b.stages = append(b.stages, bson.D{{Key: "$limit", Value: limit}})
+	return b
+}
+
+//Facet adds a $facet stage running the received sub-pipelines in parallel
+func (b *Builder) Facet(facets bson.D) *Builder {
+	b.stages = append(b.stages, bson.D{{Key: "$facet", Value: facets}})
+	return b
+}
+
+//Pipeline returns the built mongo.Pipeline
+func (b *Builder) Pipeline() mongo.Pipeline {
+	return b.stages
+}
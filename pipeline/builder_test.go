@@ -0,0 +1,46 @@
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// TestBuilder_Pipeline checks that Pipeline returns the stages in the order they were added
+func TestBuilder_Pipeline(t *testing.T) {
+	// Arrange
+	b := New().
+		Match(bson.D{{Key: "active", Value: true}}).
+		Group("$category", bson.D{{Key: "total", Value: bson.D{{Key: "$sum", Value: 1}}}}).
+		Lookup("orders", "_id", "userId", "orders").
+		Unwind("orders").
+		Project(bson.D{{Key: "_id", Value: 0}}).
+		Sort(bson.D{{Key: "total", Value: -1}}).
+		Skip(5).
+		Limit(10).
+		Facet(bson.D{{Key: "count", Value: bson.A{bson.D{{Key: "$count", Value: "total"}}}}})
+
+	// Act
+	result := b.Pipeline()
+
+	// Assert
+	expected := mongo.Pipeline{
+		bson.D{{Key: "$match", Value: bson.D{{Key: "active", Value: true}}}},
+		bson.D{{Key: "$group", Value: bson.D{{Key: "_id", Value: "$category"}, {Key: "total", Value: bson.D{{Key: "$sum", Value: 1}}}}}},
+		bson.D{{Key: "$lookup", Value: bson.D{
+			{Key: "from", Value: "orders"},
+			{Key: "localField", Value: "_id"},
+			{Key: "foreignField", Value: "userId"},
+			{Key: "as", Value: "orders"},
+		}}},
+		bson.D{{Key: "$unwind", Value: "$orders"}},
+		bson.D{{Key: "$project", Value: bson.D{{Key: "_id", Value: 0}}}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "total", Value: -1}}}},
+		bson.D{{Key: "$skip", Value: int64(5)}},
+		bson.D{{Key: "$limit", Value: int64(10)}},
+		bson.D{{Key: "$facet", Value: bson.D{{Key: "count", Value: bson.A{bson.D{{Key: "$count", Value: "total"}}}}}}},
+	}
+	assert.Equal(t, expected, result)
+}
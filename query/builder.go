@@ -0,0 +1,149 @@
+package query
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+//Builder is a fluent, typed filter builder that compiles into a bson.D filter plus *options.FindOptions
+type Builder struct {
+	conditions []bson.E
+	sort       bson.D
+	projection bson.D
+	skip       *int64
+	limit      *int64
+}
+
+//New creates an empty Builder
+func New() *Builder {
+	return &Builder{}
+}
+
+//Eq adds a field equal to value condition
+func (b *Builder) Eq(field string, value interface{}) *Builder {
+	return b.op(field, "$eq", value)
+}
+
+//Ne adds a field not equal to value condition
+func (b *Builder) Ne(field string, value interface{}) *Builder {
+	return b.op(field, "$ne", value)
+}
+
+//In adds a field contained in the received values condition
+func (b *Builder) In(field string, values ...interface{}) *Builder {
+	return b.op(field, "$in", values)
+}
+
+//Gt adds a field greater than value condition
+func (b *Builder) Gt(field string, value interface{}) *Builder {
+	return b.op(field, "$gt", value)
+}
+
+//Gte adds a field greater than or equal to value condition
+func (b *Builder) Gte(field string, value interface{}) *Builder {
+	return b.op(field, "$gte", value)
+}
+
+//Lt adds a field lower than value condition
+func (b *Builder) Lt(field string, value interface{}) *Builder {
+	return b.op(field, "$lt", value)
+}
+
+//Lte adds a field lower than or equal to value condition
+func (b *Builder) Lte(field string, value interface{}) *Builder {
+	return b.op(field, "$lte", value)
+}
+
+//Regex adds a field matching the received pattern and options condition
+func (b *Builder) Regex(field, pattern, options string) *Builder {
+	return b.op(field, "$regex", primitive.Regex{Pattern: pattern, Options: options})
+}
+
+//Exists adds a field existence condition
+func (b *Builder) Exists(field string, exists bool) *Builder {
+	return b.op(field, "$exists", exists)
+}
+
+func (b *Builder) op(field, operator string, value interface{}) *Builder {
+	b.conditions = append(b.conditions, bson.E{Key: field, Value: bson.M{operator: value}})
+	return b
+}
+
+//And combines the received builders with a logical AND
+func And(builders ...*Builder) *Builder {
+	return &Builder{conditions: []bson.E{{Key: "$and", Value: toFilters(builders)}}}
+}
+
+//Or combines the received builders with a logical OR
+func Or(builders ...*Builder) *Builder {
+	return &Builder{conditions: []bson.E{{Key: "$or", Value: toFilters(builders)}}}
+}
+
+//Not negates the received builder
+func Not(b *Builder) *Builder {
+	return &Builder{conditions: []bson.E{{Key: "$nor", Value: bson.A{b.Filter()}}}}
+}
+
+func toFilters(builders []*Builder) bson.A {
+	filters := make(bson.A, 0, len(builders))
+	for _, b := range builders {
+		filters = append(filters, b.Filter())
+	}
+	return filters
+}
+
+//SortBy adds an ascending, or descending, sort on the received field
+func (b *Builder) SortBy(field string, asc bool) *Builder {
+	direction := 1
+	if !asc {
+		direction = -1
+	}
+	b.sort = append(b.sort, bson.E{Key: field, Value: direction})
+	return b
+}
+
+//Project restricts the returned fields to the received list
+func (b *Builder) Project(fields ...string) *Builder {
+	for _, field := range fields {
+		b.projection = append(b.projection, bson.E{Key: field, Value: 1})
+	}
+	return b
+}
+
+//Skip sets the number of documents to skip
+func (b *Builder) Skip(skip int64) *Builder {
+	b.skip = &skip
+	return b
+}
+
+//Limit sets the maximum number of documents to return
+func (b *Builder) Limit(limit int64) *Builder {
+	b.limit = &limit
+	return b
+}
+
+//Filter compiles the Builder into a bson.D filter
+func (b *Builder) Filter() bson.D {
+	filter := bson.D{}
+	filter = append(filter, b.conditions...)
+	return filter
+}
+
+//Options compiles the Builder into *options.FindOptions
+func (b *Builder) Options() *options.FindOptions {
+	opts := options.Find()
+	if len(b.sort) > 0 {
+		opts.SetSort(b.sort)
+	}
+	if len(b.projection) > 0 {
+		opts.SetProjection(b.projection)
+	}
+	if b.skip != nil {
+		opts.SetSkip(*b.skip)
+	}
+	if b.limit != nil {
+		opts.SetLimit(*b.limit)
+	}
+	return opts
+}
@@ -0,0 +1,92 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// TestBuilder_Filter checks that Filter compiles the added conditions into a bson.D
+func TestBuilder_Filter(t *testing.T) {
+	// Arrange
+	b := New().Eq("name", "foo").Gte("age", 18)
+
+	// Act
+	filter := b.Filter()
+
+	// Assert
+	expected := bson.D{
+		{Key: "name", Value: bson.M{"$eq": "foo"}},
+		{Key: "age", Value: bson.M{"$gte": 18}},
+	}
+	assert.Equal(t, expected, filter)
+}
+
+// TestAnd checks that And wraps the received builders' filters under a $and operator
+func TestAnd(t *testing.T) {
+	// Arrange
+	b := And(New().Eq("name", "foo"), New().Gte("age", 18))
+
+	// Act
+	filter := b.Filter()
+
+	// Assert
+	expected := bson.D{
+		{Key: "$and", Value: bson.A{
+			bson.D{{Key: "name", Value: bson.M{"$eq": "foo"}}},
+			bson.D{{Key: "age", Value: bson.M{"$gte": 18}}},
+		}},
+	}
+	assert.Equal(t, expected, filter)
+}
+
+// TestOr checks that Or wraps the received builders' filters under a $or operator
+func TestOr(t *testing.T) {
+	// Arrange
+	b := Or(New().Eq("name", "foo"), New().Eq("name", "bar"))
+
+	// Act
+	filter := b.Filter()
+
+	// Assert
+	expected := bson.D{
+		{Key: "$or", Value: bson.A{
+			bson.D{{Key: "name", Value: bson.M{"$eq": "foo"}}},
+			bson.D{{Key: "name", Value: bson.M{"$eq": "bar"}}},
+		}},
+	}
+	assert.Equal(t, expected, filter)
+}
+
+// TestNot checks that Not wraps the received builder's filter under a $nor operator
+func TestNot(t *testing.T) {
+	// Arrange
+	b := Not(New().Eq("name", "foo"))
+
+	// Act
+	filter := b.Filter()
+
+	// Assert
+	expected := bson.D{
+		{Key: "$nor", Value: bson.A{
+			bson.D{{Key: "name", Value: bson.M{"$eq": "foo"}}},
+		}},
+	}
+	assert.Equal(t, expected, filter)
+}
+
+// TestBuilder_Options checks that Options compiles the sort, projection, skip and limit into FindOptions
+func TestBuilder_Options(t *testing.T) {
+	// Arrange
+	b := New().SortBy("name", true).SortBy("age", false).Project("name", "age").Skip(5).Limit(10)
+
+	// Act
+	opts := b.Options()
+
+	// Assert
+	assert.Equal(t, bson.D{{Key: "name", Value: 1}, {Key: "age", Value: -1}}, opts.Sort)
+	assert.Equal(t, bson.D{{Key: "name", Value: 1}, {Key: "age", Value: 1}}, opts.Projection)
+	assert.Equal(t, int64(5), *opts.Skip)
+	assert.Equal(t, int64(10), *opts.Limit)
+}
@@ -1,12 +1,117 @@
 package repository
 
-import "context"
-
-// Repository interface to be used as a port
-type Repository interface {
-	Create(ctx context.Context, entity interface{}) (string, error)
-	Get(ctx context.Context, filter map[string]interface{}, skip, take *int) ([]interface{}, error)
-	GetByID(ctx context.Context, ID string) (interface{}, error)
-	Update(ctx context.Context, ID string, entity interface{}) error
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/scanet9/scv-mongo-framework/query"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+//ErrConflict is returned by Update when optimistic concurrency is enabled and the entity was modified
+//by another write since it was read
+var ErrConflict = errors.New("the entity was modified by another write, refresh and retry")
+
+//BulkOp represents a single write operation to run as part of a BulkWrite call.
+//Exactly one of Insert, Update or Delete should be set.
+type BulkOp[T any] struct {
+	Insert *T
+	Filter map[string]interface{}
+	Update *T
+	Delete bool
+	Upsert bool
+}
+
+//BulkOptions configures how a BulkWrite call is executed
+type BulkOptions struct {
+	Ordered bool
+}
+
+//BulkResult reports the outcome of a BulkWrite call, including the per-operation error indexes so that
+//callers can retry the failing subset
+type BulkResult struct {
+	InsertedIDs   []primitive.ObjectID
+	MatchedCount  int64
+	ModifiedCount int64
+	DeletedCount  int64
+	UpsertedCount int64
+	Errors        map[int]error
+}
+
+//QueryOptions configures soft-delete visibility for Get, GetByID and Find
+type QueryOptions struct {
+	IncludeDeleted bool
+}
+
+//QueryOption configures a QueryOptions
+type QueryOption func(*QueryOptions)
+
+//WithDeleted makes Get, GetByID and Find include soft-deleted documents when include is true,
+//instead of the default behaviour of excluding them
+func WithDeleted(include bool) QueryOption {
+	return func(o *QueryOptions) { o.IncludeDeleted = include }
+}
+
+//ApplyQueryOptions builds a QueryOptions from the received options, for adapters implementing Repository
+func ApplyQueryOptions(opts ...QueryOption) QueryOptions {
+	var o QueryOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+//Timestamps is an embeddable mixin that opts an entity into automatic CreatedAt/UpdatedAt management
+//by a repository constructed with the timestamps behavior enabled
+type Timestamps struct {
+	CreatedAt time.Time `bson:"createdAt,omitempty"`
+	UpdatedAt time.Time `bson:"updatedAt,omitempty"`
+}
+
+//SetCreatedAt sets the CreatedAt field
+func (t *Timestamps) SetCreatedAt(at time.Time) { t.CreatedAt = at }
+
+//SetUpdatedAt sets the UpdatedAt field
+func (t *Timestamps) SetUpdatedAt(at time.Time) { t.UpdatedAt = at }
+
+//VersionedDocument is an embeddable mixin that opts an entity into optimistic concurrency control
+//by a repository constructed with the optimistic concurrency behavior enabled
+type VersionedDocument struct {
+	Version int64 `bson:"_version"`
+}
+
+//GetVersion returns the current version
+func (v *VersionedDocument) GetVersion() int64 { return v.Version }
+
+//SetVersion sets the current version
+func (v *VersionedDocument) SetVersion(version int64) { v.Version = version }
+
+//SoftDeletable is an embeddable mixin that opts an entity into soft-delete semantics by a repository
+//constructed with the soft-delete behavior enabled
+type SoftDeletable struct {
+	DeletedAt *time.Time `bson:"deletedAt,omitempty"`
+}
+
+//GetDeletedAt returns the DeletedAt field, nil if the entity has not been soft-deleted
+func (s *SoftDeletable) GetDeletedAt() *time.Time { return s.DeletedAt }
+
+//SetDeletedAt sets the DeletedAt field
+func (s *SoftDeletable) SetDeletedAt(at *time.Time) { s.DeletedAt = at }
+
+// Repository interface to be used as a port for a document of type T
+type Repository[T any] interface {
+	Create(ctx context.Context, entity T) (primitive.ObjectID, error)
+	Get(ctx context.Context, filter map[string]interface{}, skip, take *int, opts ...QueryOption) ([]T, error)
+	GetByID(ctx context.Context, ID string, opts ...QueryOption) (*T, error)
+	Update(ctx context.Context, ID string, entity T) error
 	Delete(ctx context.Context, ID string) error
+	Find(ctx context.Context, q *query.Builder, opts ...QueryOption) ([]T, error)
+	Count(ctx context.Context, q *query.Builder) (int64, error)
+	Aggregate(ctx context.Context, p mongo.Pipeline, result interface{}) error
+	CreateMany(ctx context.Context, entities []T) ([]primitive.ObjectID, error)
+	UpdateMany(ctx context.Context, filter map[string]interface{}, update T) (matchedCount int64, modifiedCount int64, err error)
+	DeleteMany(ctx context.Context, filter map[string]interface{}) (int64, error)
+	BulkWrite(ctx context.Context, ops []BulkOp[T], opts BulkOptions) (BulkResult, error)
 }